@@ -0,0 +1,130 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// CheckStatus describes the on-disk state of a bookmarked path.
+type CheckStatus int
+
+const (
+	StatusOK CheckStatus = iota
+	StatusMissing
+	StatusNotDir
+	StatusDenied
+)
+
+func (s CheckStatus) String() string {
+	switch s {
+	case StatusOK:
+		return "OK"
+	case StatusMissing:
+		return "MISSING"
+	case StatusNotDir:
+		return "NOT_DIR"
+	case StatusDenied:
+		return "DENIED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// CheckOptions configures (*BookmarkStore).Check.
+type CheckOptions struct {
+	// Prune removes broken bookmarks and re-saves the store.
+	Prune bool
+	// Jobs bounds how many paths are stat'd concurrently. Zero means
+	// runtime.NumCPU().
+	Jobs int
+}
+
+// CheckResult is the outcome of checking a single bookmark.
+type CheckResult struct {
+	Bookmark Bookmark
+	Status   CheckStatus
+}
+
+// Check walks every bookmark concurrently, bounded by opts.Jobs workers,
+// and reports whether its path still exists, is still a directory, and is
+// still readable. With opts.Prune, broken bookmarks are removed and the
+// store is re-saved atomically; without it, the store is left untouched.
+func (s *BookmarkStore) Check(opts CheckOptions) ([]CheckResult, error) {
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+
+	results := make([]CheckResult, len(s.Bookmarks))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+
+	for i, b := range s.Bookmarks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, b Bookmark) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = CheckResult{Bookmark: b, Status: checkPath(b.Path)}
+		}(i, b)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Bookmark.Name < results[j].Bookmark.Name
+	})
+
+	if opts.Prune {
+		broken := map[string]bool{}
+		for _, r := range results {
+			if r.Status != StatusOK {
+				broken[r.Bookmark.Name] = true
+			}
+		}
+
+		if len(broken) > 0 {
+			err := s.mutate(func() error {
+				kept := s.Bookmarks[:0]
+				for _, b := range s.Bookmarks {
+					if !broken[b.Name] {
+						kept = append(kept, b)
+					}
+				}
+				s.Bookmarks = kept
+				return nil
+			})
+			if err != nil {
+				return results, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func checkPath(path string) CheckStatus {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return StatusDenied
+		}
+		return StatusMissing
+	}
+
+	if !info.IsDir() {
+		return StatusNotDir
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsPermission(err) {
+			return StatusDenied
+		}
+		return StatusMissing
+	}
+	f.Close()
+
+	return StatusOK
+}
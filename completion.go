@@ -0,0 +1,75 @@
+package main
+
+import "fmt"
+
+// Completion returns the shell completion script for the given shell
+// ("bash", "zsh", or "fish"). Each script drives completion for bm go,
+// bm remove, and bm tag from the hidden __complete-names command, which
+// reads the live bookmark file at completion time.
+func Completion(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashCompletion, nil
+	case "zsh":
+		return zshCompletion, nil
+	case "fish":
+		return fishCompletion, nil
+	default:
+		return "", fmt.Errorf("Unknown shell: %s (expected 'bash', 'zsh', or 'fish')", shell)
+	}
+}
+
+const bashCompletion = `_bm_complete_names() {
+    local cur
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    COMPREPLY=( $(compgen -W "$(bm __complete-names)" -- "$cur") )
+}
+
+_bm() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=( $(compgen -W "add remove list go tag import export check pick completion" -- "$cur") )
+        return
+    fi
+
+    case "$prev" in
+        go|remove|tag)
+            _bm_complete_names
+            ;;
+    esac
+}
+
+complete -F _bm bm
+`
+
+const zshCompletion = `#compdef bm
+
+_bm() {
+    local -a names
+
+    if (( CURRENT == 2 )); then
+        _values 'command' add remove list go tag import export check pick completion
+        return
+    fi
+
+    case "${words[2]}" in
+        go|remove|tag)
+            names=(${(f)"$(bm __complete-names)"})
+            _values 'bookmark' $names
+            ;;
+    esac
+}
+
+_bm
+`
+
+const fishCompletion = `function __bm_names
+    bm __complete-names
+end
+
+complete -c bm -n "__fish_use_subcommand" -a "add remove list go tag import export check pick completion"
+complete -c bm -n "__fish_seen_subcommand_from go remove tag" -a "(__bm_names)"
+`
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// jsonStorage persists bookmarks as indented JSON, the format this tool
+// has always used.
+type jsonStorage struct{}
+
+// jsonSchemaVersion is bumped whenever the on-disk JSON shape changes.
+// Version 1 files have no "version" field and no tags/description/
+// created_at on their bookmarks; Load upgrades them transparently.
+const jsonSchemaVersion = 2
+
+type jsonFile struct {
+	Version   int        `json:"version"`
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+func (jsonStorage) Load(path string) ([]Bookmark, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read bookmarks file: %w", err)
+	}
+
+	var file jsonFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("Failed to parse bookmarks file: %w", err)
+	}
+
+	if file.Version < jsonSchemaVersion {
+		now := time.Now()
+		for i := range file.Bookmarks {
+			if file.Bookmarks[i].CreatedAt.IsZero() {
+				file.Bookmarks[i].CreatedAt = now
+			}
+		}
+	}
+
+	return file.Bookmarks, nil
+}
+
+func (jsonStorage) Save(path string, bookmarks []Bookmark) error {
+	data, err := json.MarshalIndent(jsonFile{Version: jsonSchemaVersion, Bookmarks: bookmarks}, "", " ")
+	if err != nil {
+		return fmt.Errorf("Failed to serialize bookmarks: %w", err)
+	}
+
+	return writeFileAtomic(path, data)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONStorageLoadMigratesV1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	v1 := `{"bookmarks":[{"name":"old","path":"/tmp/old"}]}`
+	if err := os.WriteFile(path, []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bookmarks, err := jsonStorage{}.Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(bookmarks) != 1 {
+		t.Fatalf("bookmarks = %v, want 1 entry", bookmarks)
+	}
+	if bookmarks[0].CreatedAt.IsZero() {
+		t.Error("CreatedAt = zero value, want migration to backfill a timestamp")
+	}
+}
+
+func TestJSONStorageLoadPreservesV2CreatedAt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	v2 := `{"version":2,"bookmarks":[{"name":"new","path":"/tmp/new","created_at":"2020-01-02T03:04:05Z"}]}`
+	if err := os.WriteFile(path, []byte(v2), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bookmarks, err := jsonStorage{}.Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(bookmarks) != 1 {
+		t.Fatalf("bookmarks = %v, want 1 entry", bookmarks)
+	}
+	if got, want := bookmarks[0].CreatedAt.Format("2006-01-02T15:04:05Z"), "2020-01-02T03:04:05Z"; got != want {
+		t.Errorf("CreatedAt = %s, want %s (should not be overwritten by migration)", got, want)
+	}
+}
+
+func TestJSONStorageSaveWritesCurrentVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	bookmarks := []Bookmark{{Name: "a", Path: "/tmp/a"}}
+	if err := (jsonStorage{}).Save(path, bookmarks); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	roundTripped, err := jsonStorage{}.Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(roundTripped) != 1 || roundTripped[0].Name != "a" {
+		t.Errorf("roundTripped = %+v, want one bookmark named 'a'", roundTripped)
+	}
+}
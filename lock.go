@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gofrs/flock"
+)
+
+// acquireLock takes a blocking advisory lock on path+".lock", guarding the
+// read-modify-write window between loading a store and saving it so two
+// `bm` invocations running at the same time can't clobber each other.
+func acquireLock(path string) (*flock.Flock, error) {
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return nil, fmt.Errorf("Failed to lock bookmarks file: %w", err)
+	}
+	return lock, nil
+}
@@ -1,24 +1,39 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorGreen = "\033[32m"
+	colorRed   = "\033[31m"
 )
 
 type Bookmark struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`
+	Tags        []string  `json:"tags,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
 }
 
 type BookmarkStore struct {
-	Bookmarks []Bookmark `json:"bookmarks"`
+	Bookmarks []Bookmark
 	filePath  string
+	storage   Storage
 }
 
-func NewBookmarkStore() (*BookmarkStore, error) {
+// NewBookmarkStore opens the bookmark store for the given backend format
+// ("json" or "xbel", defaulting to "json"). If format is "xbel" and
+// bookmarks.xbel doesn't exist yet but a bookmarks.json does, the JSON
+// file is migrated automatically and kept alongside as a ".bak".
+func NewBookmarkStore(format string) (*BookmarkStore, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get home directory: %w", err)
@@ -29,50 +44,107 @@ func NewBookmarkStore() (*BookmarkStore, error) {
 		return nil, fmt.Errorf("Failed to create config directory: %w", err)
 	}
 
-	filePath := filepath.Join(configDir, "bookmarks.json")
+	storage, filename, err := storageForFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(configDir, filename)
 	store := &BookmarkStore{
 		filePath:  filePath,
+		storage:   storage,
 		Bookmarks: []Bookmark{},
 	}
 
-	// Load existing bookmarks
-	if _, err := os.Stat(filePath); err == nil {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to read bookmarks file: %w", err)
+	if filename == "bookmarks.xbel" {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			if migrated, err := store.migrateFromJSON(configDir); err != nil {
+				return nil, err
+			} else if migrated {
+				return store, nil
+			}
 		}
+	}
 
-		if err := json.Unmarshal(data, &store); err != nil {
-			return nil, fmt.Errorf("Failed to parse bookmarks file: %w", err)
-		}
+	bookmarks, err := storage.Load(filePath)
+	if err != nil {
+		return nil, err
 	}
+	store.Bookmarks = bookmarks
 
 	return store, nil
 }
 
-// Persist bookmarks to disk
-func (s *BookmarkStore) Save() error {
-	data, err := json.MarshalIndent(s, "", " ")
+// migrateFromJSON moves an existing bookmarks.json into the store's
+// configured backend, preserving the original as bookmarks.json.bak.
+// It reports whether a migration happened.
+func (s *BookmarkStore) migrateFromJSON(configDir string) (bool, error) {
+	jsonPath := filepath.Join(configDir, "bookmarks.json")
+	if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	bookmarks, err := (jsonStorage{}).Load(jsonPath)
 	if err != nil {
-		return fmt.Errorf("Failed to serialize bookmarks: %w", err)
+		return false, err
 	}
 
-	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
-		return fmt.Errorf("Failed to write bookmarks file: %w", err)
+	if err := os.Rename(jsonPath, jsonPath+".bak"); err != nil {
+		return false, fmt.Errorf("Failed to back up bookmarks.json: %w", err)
 	}
 
-	return nil
+	s.Bookmarks = bookmarks
+	if err := s.Save(); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
-// Add new bookmark
-func (s *BookmarkStore) Add(name, path string) error {
-	// Check if bookmark already exists
-	for _, b := range s.Bookmarks {
-		if b.Name == name {
-			return fmt.Errorf("bookmark with name '%s' already exists (points to: %s)", name, b.Path)
-		}
+// Save persists bookmarks to disk, holding an advisory lock for the
+// duration of the write so two `bm` invocations writing at the same time
+// can't clobber each other. Read-only commands never call Save, so they
+// never contend with it, and long-running consumers like Pick and Watch
+// (which also never call Save) are never blocked by it either.
+func (s *BookmarkStore) Save() error {
+	lock, err := acquireLock(s.filePath)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	return s.storage.Save(s.filePath, s.Bookmarks)
+}
+
+// mutate performs a read-modify-write under a single advisory lock: it
+// reloads the store's bookmarks from disk, runs fn against that fresh copy,
+// and saves the result before releasing the lock. Reloading under the lock
+// (rather than mutating whatever was loaded back in NewBookmarkStore) is
+// what keeps two concurrent mutating commands — two `bm add`s, an `add`
+// racing a `remove` — from one silently clobbering the other's write; fn
+// returning an error aborts without saving.
+func (s *BookmarkStore) mutate(fn func() error) error {
+	lock, err := acquireLock(s.filePath)
+	if err != nil {
+		return err
 	}
+	defer lock.Unlock()
 
+	bookmarks, err := s.storage.Load(s.filePath)
+	if err != nil {
+		return err
+	}
+	s.Bookmarks = bookmarks
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return s.storage.Save(s.filePath, s.Bookmarks)
+}
+
+// Add new bookmark
+func (s *BookmarkStore) Add(name, path string, tags []string, description string) error {
 	// Expand path if it contains ~
 	if strings.HasPrefix(path, "~") {
 		homeDir, err := os.UserHomeDir()
@@ -94,30 +166,44 @@ func (s *BookmarkStore) Add(name, path string) error {
 		return fmt.Errorf("Failed to get absolute path: %w", err)
 	}
 
-	// Check if path is already bookmarked
-	for _, b := range s.Bookmarks {
-		if b.Path == absPath {
-			return fmt.Errorf("This directory is already bookmarked as '%s'", b.Name)
+	return s.mutate(func() error {
+		// Check if bookmark already exists
+		for _, b := range s.Bookmarks {
+			if b.Name == name {
+				return fmt.Errorf("bookmark with name '%s' already exists (points to: %s)", name, b.Path)
+			}
 		}
-	}
 
-	s.Bookmarks = append(s.Bookmarks, Bookmark{
-		Name: name,
-		Path: absPath,
-	})
+		// Check if path is already bookmarked
+		for _, b := range s.Bookmarks {
+			if b.Path == absPath {
+				return fmt.Errorf("This directory is already bookmarked as '%s'", b.Name)
+			}
+		}
+
+		s.Bookmarks = append(s.Bookmarks, Bookmark{
+			Name:        name,
+			Path:        absPath,
+			Tags:        tags,
+			Description: description,
+			CreatedAt:   time.Now(),
+		})
 
-	return s.Save()
+		return nil
+	})
 }
 
 // Remove bookmark by name
 func (s *BookmarkStore) Remove(name string) error {
-	for i, b := range s.Bookmarks {
-		if b.Name == name {
-			s.Bookmarks = append(s.Bookmarks[:i], s.Bookmarks[i+1:]...)
-			return s.Save()
+	return s.mutate(func() error {
+		for i, b := range s.Bookmarks {
+			if b.Name == name {
+				s.Bookmarks = append(s.Bookmarks[:i], s.Bookmarks[i+1:]...)
+				return nil
+			}
 		}
-	}
-	return fmt.Errorf("Bookmark not found: %s", name)
+		return fmt.Errorf("Bookmark not found: %s", name)
+	})
 }
 
 // Retrieve a bookmark by name
@@ -137,10 +223,19 @@ func (s *BookmarkStore) List() []Bookmark {
 
 func printUsage() {
 	fmt.Println("Usage:")
-	fmt.Println("  bm add <name> [path]  - Add a bookmark for the current or specified directory")
+	fmt.Println("  bm add <name> [path] [--tags a,b] [--desc \"...\"] - Add a bookmark")
 	fmt.Println("  bm remove <name>      - Remove a bookmark")
-	fmt.Println("  bm list               - List all bookmarks")
+	fmt.Println("  bm list [--tag t] [--match regex] - List (or filter) bookmarks")
 	fmt.Println("  bm go <name>          - Print the path of a bookmark (use with cd command, see below)")
+	fmt.Println("  bm tag <name> +new -old - Add or remove tags on a bookmark")
+	fmt.Println("  bm import <file>      - Import bookmarks from a Netscape Bookmark File (HTML)")
+	fmt.Println("  bm export <file>      - Export bookmarks to a Netscape Bookmark File (HTML)")
+	fmt.Println("  bm check [--prune] [--jobs N] - Report (or prune) bookmarks whose path is broken")
+	fmt.Println("  bm pick               - Interactively fuzzy-pick a bookmark and print its path")
+	fmt.Println("  bm completion bash|zsh|fish - Print a shell completion script")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  --format json|xbel    - Storage backend to use (default json, or $BOOKMARK_FORMAT)")
 	fmt.Println()
 	fmt.Println("Setup:")
 	fmt.Println("  Add this to your .bashrc or .zshrc:")
@@ -148,14 +243,67 @@ func printUsage() {
 	fmt.Println("  alias goto=\"cdto\"")
 }
 
+// resolveFormat pulls a --format/--format=<value> flag out of args
+// (falling back to $BOOKMARK_FORMAT), returning the remaining args with
+// the flag removed.
+func resolveFormat(args []string) (string, []string) {
+	format := os.Getenv("BOOKMARK_FORMAT")
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--format" && i+1 < len(args):
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--format="):
+			format = strings.TrimPrefix(args[i], "--format=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	return format, rest
+}
+
+// extractFlag pulls a "flag value" or "flag=value" pair out of args,
+// returning the value (empty if absent) and the remaining args.
+func extractFlag(args []string, flag string) (string, []string) {
+	value := ""
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == flag && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], flag+"="):
+			value = strings.TrimPrefix(args[i], flag+"=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	return value, rest
+}
+
+// splitTags turns a comma-separated "foo,bar" flag value into a tag
+// slice, returning nil for an empty value.
+func splitTags(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
 func main() {
-	store, err := NewBookmarkStore()
+	format, args := resolveFormat(os.Args[1:])
+
+	store, err := NewBookmarkStore(format)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	args := os.Args[1:]
 	if len(args) == 0 {
 		printUsage()
 		return
@@ -165,6 +313,9 @@ func main() {
 
 	switch command {
 	case "add":
+		tagsFlag, args := extractFlag(args, "--tags")
+		descFlag, args := extractFlag(args, "--desc")
+
 		if len(args) < 2 {
 			fmt.Println("Error: Missing bookmark name")
 			printUsage()
@@ -184,7 +335,7 @@ func main() {
 			}
 		}
 
-		if err := store.Add(name, path); err != nil {
+		if err := store.Add(name, path, splitTags(tagsFlag), descFlag); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -217,7 +368,16 @@ func main() {
 		}
 
 	case "list":
-		bookmarks := store.List()
+		tagFlag, args := extractFlag(args, "--tag")
+		matchFlag, _ := extractFlag(args, "--match")
+
+		var bookmarks []Bookmark
+		if tagFlag != "" || matchFlag != "" {
+			bookmarks = store.Query(QueryOptions{TagAny: splitTags(tagFlag), Match: matchFlag})
+		} else {
+			bookmarks = store.List()
+		}
+
 		if len(bookmarks) == 0 {
 			fmt.Println("No bookmarks saved.")
 			return
@@ -225,9 +385,143 @@ func main() {
 
 		fmt.Println("Bookmarks:")
 		for _, b := range bookmarks {
-			fmt.Printf("  %s -> %s\n", b.Name, b.Path)
+			line := fmt.Sprintf("  %s -> %s", b.Name, b.Path)
+			if len(b.Tags) > 0 {
+				line += fmt.Sprintf(" [%s]", strings.Join(b.Tags, ", "))
+			}
+			fmt.Println(line)
 		}
 
+	case "import":
+		if len(args) < 2 {
+			fmt.Println("Error: Missing file to import")
+			printUsage()
+			os.Exit(1)
+		}
+		f, err := os.Open(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to open %s: %v\n", args[1], err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		added, skipped, warnings, err := store.ImportNetscape(f)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: skipping '%s': %s\n", w.Name, w.Reason)
+		}
+		fmt.Printf("Imported %d bookmark(s), skipped %d\n", added, skipped)
+
+	case "export":
+		if len(args) < 2 {
+			fmt.Println("Error: Missing destination file")
+			printUsage()
+			os.Exit(1)
+		}
+		f, err := os.Create(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to create %s: %v\n", args[1], err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		if err := store.ExportNetscape(f); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Exported %d bookmark(s) to %s\n", len(store.Bookmarks), args[1])
+
+	case "tag":
+		if len(args) < 3 {
+			fmt.Println("Error: Usage: bm tag <name> +newtag -oldtag")
+			printUsage()
+			os.Exit(1)
+		}
+		name := args[1]
+		if err := store.Tag(name, args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Updated tags for '%s'\n", name)
+
+	case "pick":
+		path, err := store.Pick()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(path)
+
+	case "completion":
+		if len(args) < 2 {
+			fmt.Println("Error: Usage: bm completion bash|zsh|fish")
+			os.Exit(1)
+		}
+		script, err := Completion(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
+
+	case "__complete-names":
+		// Hidden: used by shell completion scripts, not meant for users.
+		for _, b := range store.Bookmarks {
+			fmt.Println(b.Name)
+		}
+
+	case "check":
+		opts := CheckOptions{}
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "--prune":
+				opts.Prune = true
+			case "--jobs":
+				if i+1 >= len(args) {
+					fmt.Fprintln(os.Stderr, "Error: --jobs requires a value")
+					os.Exit(1)
+				}
+				jobs, err := strconv.Atoi(args[i+1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: invalid --jobs value: %s\n", args[i+1])
+					os.Exit(1)
+				}
+				opts.Jobs = jobs
+				i++
+			}
+		}
+
+		results, err := store.Check(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		broken := 0
+		for _, r := range results {
+			color := colorGreen
+			if r.Status != StatusOK {
+				color = colorRed
+				broken++
+			}
+			fmt.Printf("  %s%-7s%s %s -> %s\n", color, r.Status, colorReset, r.Bookmark.Name, r.Bookmark.Path)
+		}
+
+		if broken == 0 {
+			fmt.Println("All bookmarks OK.")
+			return
+		}
+
+		if opts.Prune {
+			fmt.Printf("Pruned %d broken bookmark(s).\n", broken)
+		} else {
+			fmt.Printf("%d broken bookmark(s). Run with --prune to remove them.\n", broken)
+		}
+		os.Exit(1)
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
 		printUsage()
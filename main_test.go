@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestAddConcurrentDoesNotLoseWrites simulates two `bm add` invocations
+// racing against the same store file, each starting from its own freshly
+// loaded (empty) BookmarkStore — the way two separate process invocations
+// would. Neither write should be lost.
+func TestAddConcurrentDoesNotLoseWrites(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "bookmarks.json")
+
+	const n = 5
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		projDir := filepath.Join(dir, fmt.Sprintf("project%d", i))
+		if err := os.MkdirAll(projDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			store := &BookmarkStore{storage: jsonStorage{}, filePath: filePath}
+			errs[i] = store.Add(fmt.Sprintf("bm%d", i), path, nil, "")
+		}(i, projDir)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Add #%d returned error: %v", i, err)
+		}
+	}
+
+	bookmarks, err := jsonStorage{}.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(bookmarks) != n {
+		t.Fatalf("bookmarks on disk = %d, want %d (a concurrent Add lost a write)", len(bookmarks), n)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	netscapeFolderRe = regexp.MustCompile(`(?i)<H3[^>]*>(.*?)</H3>`)
+	netscapeLinkRe   = regexp.MustCompile(`(?i)<A\s+([^>]*)>(.*?)</A>`)
+	netscapeAttrRe   = regexp.MustCompile(`(?i)([a-zA-Z_]+)="([^"]*)"`)
+	netscapeDescRe   = regexp.MustCompile(`(?i)^<DD>(.*)$`)
+)
+
+// ImportWarning is a Netscape entry that was skipped during import, and
+// why, so callers other than main (a future TUI, a test) can inspect the
+// list instead of scraping stderr.
+type ImportWarning struct {
+	Name   string
+	Reason string
+}
+
+// ImportNetscape reads a Netscape Bookmark File Format document (the format
+// exported by every major browser, and used by tools like Shiori) and adds
+// each entry as a bookmark. A "file:///abs/path" HREF (or a custom PATH
+// attribute) becomes Bookmark.Path, the anchor text becomes Bookmark.Name,
+// and nested <DL> folders are flattened into a "/"-joined name prefix.
+// Entries whose path does not exist on this machine are skipped and
+// returned as warnings rather than failing the whole import; entries that
+// duplicate an existing name or path are also skipped, matching Add's
+// dedup rule, but don't produce a warning.
+func (s *BookmarkStore) ImportNetscape(r io.Reader) (added, skipped int, warnings []ImportWarning, err error) {
+	mutateErr := s.mutate(func() error {
+		var parseErr error
+		added, skipped, warnings, parseErr = s.scanNetscape(r)
+		return parseErr
+	})
+	if mutateErr != nil {
+		return added, skipped, warnings, mutateErr
+	}
+
+	return added, skipped, warnings, nil
+}
+
+// scanNetscape does the actual parsing and appending for ImportNetscape,
+// run by (*BookmarkStore).mutate against a freshly-loaded s.Bookmarks so a
+// concurrent import can't race another mutating command.
+func (s *BookmarkStore) scanNetscape(r io.Reader) (added, skipped int, warnings []ImportWarning, err error) {
+	scanner := bufio.NewScanner(r)
+	var folders []string
+	lastAdded := -1
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if m := netscapeFolderRe.FindStringSubmatch(line); m != nil {
+			folders = append(folders, html.UnescapeString(m[1]))
+			continue
+		}
+
+		if strings.HasPrefix(strings.ToUpper(line), "</DL") {
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+			continue
+		}
+
+		if m := netscapeDescRe.FindStringSubmatch(line); m != nil {
+			if lastAdded >= 0 {
+				s.Bookmarks[lastAdded].Description = html.UnescapeString(m[1])
+			}
+			continue
+		}
+
+		m := netscapeLinkRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		lastAdded = -1
+
+		attrs := map[string]string{}
+		for _, a := range netscapeAttrRe.FindAllStringSubmatch(m[1], -1) {
+			attrs[strings.ToUpper(a[1])] = html.UnescapeString(a[2])
+		}
+
+		path := attrs["PATH"]
+		if path == "" {
+			path = strings.TrimPrefix(attrs["HREF"], "file://")
+		}
+		if path == "" {
+			continue
+		}
+
+		name := html.UnescapeString(strings.TrimSpace(m[2]))
+		if len(folders) > 0 {
+			name = strings.Join(folders, "/") + "/" + name
+		}
+
+		var tags []string
+		if raw := attrs["TAGS"]; raw != "" {
+			tags = strings.Split(raw, ",")
+		}
+
+		if _, statErr := os.Stat(path); statErr != nil {
+			warnings = append(warnings, ImportWarning{Name: name, Reason: statErr.Error()})
+			skipped++
+			continue
+		}
+
+		absPath, absErr := filepath.Abs(path)
+		if absErr != nil {
+			warnings = append(warnings, ImportWarning{Name: name, Reason: absErr.Error()})
+			skipped++
+			continue
+		}
+
+		duplicate := false
+		for _, b := range s.Bookmarks {
+			if b.Name == name || b.Path == absPath {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			skipped++
+			continue
+		}
+
+		s.Bookmarks = append(s.Bookmarks, Bookmark{Name: name, Path: absPath, Tags: tags})
+		lastAdded = len(s.Bookmarks) - 1
+		added++
+	}
+
+	if scanErr := scanner.Err(); scanErr != nil {
+		return added, skipped, warnings, fmt.Errorf("Failed to read bookmark file: %w", scanErr)
+	}
+
+	return added, skipped, warnings, nil
+}
+
+// ExportNetscape writes every bookmark as a Netscape Bookmark File Format
+// document, the portable format understood by every browser and by tools
+// like Shiori. A bookmark whose name has a "/"-separated prefix (as
+// produced by ImportNetscape) is grouped under a <DL> folder named after
+// that prefix.
+func (s *BookmarkStore) ExportNetscape(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, `<!DOCTYPE NETSCAPE-Bookmark-file-1>`)
+	fmt.Fprintln(bw, `<!-- This is an automatically generated file. -->`)
+	fmt.Fprintln(bw, `<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">`)
+	fmt.Fprintln(bw, `<TITLE>Bookmarks</TITLE>`)
+	fmt.Fprintln(bw, `<H1>Bookmarks</H1>`)
+	fmt.Fprintln(bw, `<DL><p>`)
+
+	grouped := map[string][]Bookmark{}
+	var folders []string
+	for _, b := range s.Bookmarks {
+		folder := ""
+		name := b.Name
+		if i := strings.LastIndex(b.Name, "/"); i >= 0 {
+			folder = b.Name[:i]
+			name = b.Name[i+1:]
+		}
+		if _, ok := grouped[folder]; !ok {
+			folders = append(folders, folder)
+		}
+		grouped[folder] = append(grouped[folder], Bookmark{Name: name, Path: b.Path, Tags: b.Tags, Description: b.Description})
+	}
+	sort.Strings(folders)
+
+	for _, folder := range folders {
+		indent := "    "
+		if folder != "" {
+			fmt.Fprintf(bw, "%s<DT><H3>%s</H3>\n", indent, html.EscapeString(folder))
+			fmt.Fprintf(bw, "%s<DL><p>\n", indent)
+			indent += "    "
+		}
+		for _, b := range grouped[folder] {
+			fmt.Fprintf(bw, "%s<DT><A HREF=\"file://%s\"", indent, html.EscapeString(b.Path))
+			if len(b.Tags) > 0 {
+				fmt.Fprintf(bw, " TAGS=\"%s\"", html.EscapeString(strings.Join(b.Tags, ",")))
+			}
+			fmt.Fprintf(bw, ">%s</A>\n", html.EscapeString(b.Name))
+			if b.Description != "" {
+				fmt.Fprintf(bw, "%s<DD>%s\n", indent, html.EscapeString(b.Description))
+			}
+		}
+		if folder != "" {
+			fmt.Fprintf(bw, "    </DL><p>\n")
+		}
+	}
+
+	fmt.Fprintln(bw, `</DL><p>`)
+
+	return bw.Flush()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// nopStorage is a Storage that never touches disk, so tests can exercise
+// BookmarkStore methods that call Save without racing real files.
+type nopStorage struct{}
+
+func (nopStorage) Load(string) ([]Bookmark, error) { return nil, nil }
+func (nopStorage) Save(string, []Bookmark) error    { return nil }
+
+func newTestStore(t *testing.T) *BookmarkStore {
+	t.Helper()
+	return &BookmarkStore{
+		storage:  nopStorage{},
+		filePath: filepath.Join(t.TempDir(), "bookmarks.json"),
+	}
+}
+
+func TestImportNetscape(t *testing.T) {
+	projDir := filepath.Join(t.TempDir(), "project")
+	if err := os.MkdirAll(projDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	html := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3>work</H3>
+    <DL><p>
+        <DT><A HREF="file://` + projDir + `" TAGS="go,backend">Project</A>
+        <DD>My main project
+        <DT><A HREF="file:///does/not/exist">Ghost</A>
+    </DL><p>
+</DL><p>
+`
+
+	store := newTestStore(t)
+	added, skipped, warnings, err := store.ImportNetscape(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ImportNetscape returned error: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Name, "Ghost") {
+		t.Errorf("warnings = %+v, want one warning naming 'Ghost'", warnings)
+	}
+
+	if len(store.Bookmarks) != 1 {
+		t.Fatalf("Bookmarks = %v, want 1 entry", store.Bookmarks)
+	}
+	b := store.Bookmarks[0]
+	if b.Name != "work/Project" {
+		t.Errorf("Name = %q, want %q", b.Name, "work/Project")
+	}
+	if b.Description != "My main project" {
+		t.Errorf("Description = %q, want %q", b.Description, "My main project")
+	}
+	if want := []string{"go", "backend"}; !equalStrings(b.Tags, want) {
+		t.Errorf("Tags = %v, want %v", b.Tags, want)
+	}
+}
+
+func TestExportImportNetscapeRoundTrip(t *testing.T) {
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+
+	store := newTestStore(t)
+	store.Bookmarks = []Bookmark{
+		{Name: "work/alpha", Path: dirA, Tags: []string{"a", "b"}, Description: "first"},
+		{Name: "beta", Path: dirB},
+	}
+
+	var buf bytes.Buffer
+	if err := store.ExportNetscape(&buf); err != nil {
+		t.Fatalf("ExportNetscape returned error: %v", err)
+	}
+
+	reimported := newTestStore(t)
+	added, skipped, warnings, err := reimported.ImportNetscape(&buf)
+	if err != nil {
+		t.Fatalf("ImportNetscape (re-import) returned error: %v", err)
+	}
+	if skipped != 0 || len(warnings) != 0 {
+		t.Fatalf("re-import skipped %d with warnings %+v, want none", skipped, warnings)
+	}
+	if added != 2 {
+		t.Fatalf("added = %d, want 2", added)
+	}
+
+	sort.Slice(reimported.Bookmarks, func(i, j int) bool {
+		return reimported.Bookmarks[i].Name < reimported.Bookmarks[j].Name
+	})
+
+	if got := reimported.Bookmarks[1]; got.Name != "work/alpha" || got.Description != "first" || !equalStrings(got.Tags, []string{"a", "b"}) {
+		t.Errorf("re-imported 'work/alpha' = %+v", got)
+	}
+	if got := reimported.Bookmarks[0]; got.Name != "beta" || got.Path != dirB {
+		t.Errorf("re-imported 'beta' = %+v", got)
+	}
+}
+
+func TestExportNetscapeEscapesPathAttribute(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), `has"quote`)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newTestStore(t)
+	store.Bookmarks = []Bookmark{{Name: "a", Path: dir}}
+
+	var buf bytes.Buffer
+	if err := store.ExportNetscape(&buf); err != nil {
+		t.Fatalf("ExportNetscape returned error: %v", err)
+	}
+
+	reimported := newTestStore(t)
+	added, skipped, warnings, err := reimported.ImportNetscape(&buf)
+	if err != nil {
+		t.Fatalf("ImportNetscape returned error: %v", err)
+	}
+	if added != 1 || skipped != 0 || len(warnings) != 0 {
+		t.Fatalf("added=%d skipped=%d warnings=%+v, want a clean re-import of the quoted path", added, skipped, warnings)
+	}
+	if got := reimported.Bookmarks[0].Path; got != dir {
+		t.Errorf("Path = %q, want %q (an unescaped \" in HREF corrupts the attribute)", got, dir)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ktr0731/go-fuzzyfinder"
+)
+
+// Pick launches an interactive fuzzy finder over every bookmark, matching
+// against name, path, and tags, and returns the chosen bookmark's path so
+// callers can compose it with the existing cdto shell function:
+// function cdto() { cd "$(bm pick)" }
+func (s *BookmarkStore) Pick() (string, error) {
+	if len(s.Bookmarks) == 0 {
+		return "", fmt.Errorf("No bookmarks saved.")
+	}
+
+	idx, err := fuzzyfinder.Find(
+		s.Bookmarks,
+		func(i int) string {
+			b := s.Bookmarks[i]
+			if len(b.Tags) > 0 {
+				return fmt.Sprintf("%s (%s) [%s]", b.Name, b.Path, strings.Join(b.Tags, ", "))
+			}
+			return fmt.Sprintf("%s (%s)", b.Name, b.Path)
+		},
+		fuzzyfinder.WithPromptString("bookmark> "),
+	)
+	if err != nil {
+		return "", fmt.Errorf("No bookmark selected: %w", err)
+	}
+
+	return s.Bookmarks[idx].Path, nil
+}
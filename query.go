@@ -0,0 +1,88 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// QueryOptions filters and sorts bookmarks for (*BookmarkStore).Query.
+type QueryOptions struct {
+	// TagAny matches a bookmark having at least one of these tags.
+	TagAny []string
+	// TagAll matches a bookmark having every one of these tags.
+	TagAll []string
+	// Match is tested against Name and Path. If it compiles as a regular
+	// expression it's used as one; otherwise it's a plain substring.
+	Match string
+	// Sort orders the results: "name" (default), "recency", or "path".
+	Sort string
+}
+
+// Query returns the bookmarks matching filter, in the requested order.
+func (s *BookmarkStore) Query(filter QueryOptions) []Bookmark {
+	var re *regexp.Regexp
+	if filter.Match != "" {
+		re, _ = regexp.Compile(filter.Match)
+	}
+
+	var results []Bookmark
+	for _, b := range s.Bookmarks {
+		if len(filter.TagAny) > 0 && !hasAnyTag(b.Tags, filter.TagAny) {
+			continue
+		}
+		if len(filter.TagAll) > 0 && !hasAllTags(b.Tags, filter.TagAll) {
+			continue
+		}
+		if filter.Match != "" && !matches(b, filter.Match, re) {
+			continue
+		}
+		results = append(results, b)
+	}
+
+	switch filter.Sort {
+	case "recency":
+		sort.Slice(results, func(i, j int) bool { return results[i].CreatedAt.After(results[j].CreatedAt) })
+	case "path":
+		sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+	default:
+		sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	}
+
+	return results
+}
+
+func matches(b Bookmark, pattern string, re *regexp.Regexp) bool {
+	if re != nil {
+		return re.MatchString(b.Name) || re.MatchString(b.Path)
+	}
+	return strings.Contains(b.Name, pattern) || strings.Contains(b.Path, pattern)
+}
+
+func hasAnyTag(tags, want []string) bool {
+	set := tagSet(tags)
+	for _, w := range want {
+		if set[w] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAllTags(tags, want []string) bool {
+	set := tagSet(tags)
+	for _, w := range want {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func tagSet(tags []string) map[string]bool {
+	set := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		set[t] = true
+	}
+	return set
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryTagFilters(t *testing.T) {
+	store := &BookmarkStore{Bookmarks: []Bookmark{
+		{Name: "a", Path: "/a", Tags: []string{"go", "cli"}},
+		{Name: "b", Path: "/b", Tags: []string{"go"}},
+		{Name: "c", Path: "/c", Tags: []string{"rust"}},
+	}}
+
+	any := store.Query(QueryOptions{TagAny: []string{"cli", "rust"}})
+	if got := names(any); !equalStrings(got, []string{"a", "c"}) {
+		t.Errorf("TagAny result = %v, want [a c]", got)
+	}
+
+	all := store.Query(QueryOptions{TagAll: []string{"go", "cli"}})
+	if got := names(all); !equalStrings(got, []string{"a"}) {
+		t.Errorf("TagAll result = %v, want [a]", got)
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	store := &BookmarkStore{Bookmarks: []Bookmark{
+		{Name: "work/api", Path: "/srv/api"},
+		{Name: "work/web", Path: "/srv/web"},
+		{Name: "personal/notes", Path: "/home/notes"},
+	}}
+
+	substr := store.Query(QueryOptions{Match: "work/"})
+	if got := names(substr); !equalStrings(got, []string{"work/api", "work/web"}) {
+		t.Errorf("substring match = %v, want [work/api work/web]", got)
+	}
+
+	regex := store.Query(QueryOptions{Match: "^work/(api|web)$"})
+	if got := names(regex); !equalStrings(got, []string{"work/api", "work/web"}) {
+		t.Errorf("regex match = %v, want [work/api work/web]", got)
+	}
+}
+
+func TestQuerySort(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := &BookmarkStore{Bookmarks: []Bookmark{
+		{Name: "b", Path: "/z", CreatedAt: now},
+		{Name: "a", Path: "/y", CreatedAt: now.Add(time.Hour)},
+		{Name: "c", Path: "/x", CreatedAt: now.Add(-time.Hour)},
+	}}
+
+	if got := names(store.Query(QueryOptions{})); !equalStrings(got, []string{"a", "b", "c"}) {
+		t.Errorf("default sort = %v, want name order [a b c]", got)
+	}
+
+	if got := names(store.Query(QueryOptions{Sort: "recency"})); !equalStrings(got, []string{"a", "b", "c"}) {
+		t.Errorf("recency sort = %v, want most-recent-first [a b c]", got)
+	}
+
+	if got := paths(store.Query(QueryOptions{Sort: "path"})); !equalStrings(got, []string{"/x", "/y", "/z"}) {
+		t.Errorf("path sort = %v, want [/x /y /z]", got)
+	}
+}
+
+func names(bookmarks []Bookmark) []string {
+	out := make([]string, len(bookmarks))
+	for i, b := range bookmarks {
+		out[i] = b.Name
+	}
+	return out
+}
+
+func paths(bookmarks []Bookmark) []string {
+	out := make([]string, len(bookmarks))
+	for i, b := range bookmarks {
+		out[i] = b.Path
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Storage is a pluggable persistence backend for a BookmarkStore. Load
+// reads the full bookmark set from path, returning (nil, nil) if path does
+// not exist yet. Save writes the full bookmark set to path.
+type Storage interface {
+	Load(path string) ([]Bookmark, error)
+	Save(path string, bookmarks []Bookmark) error
+}
+
+// storageForFormat resolves a --format/BOOKMARK_FORMAT value to a backend
+// and the filename it persists to inside the config directory.
+func storageForFormat(format string) (Storage, string, error) {
+	switch format {
+	case "", "json":
+		return jsonStorage{}, "bookmarks.json", nil
+	case "xbel":
+		return xbelStorage{}, "bookmarks.xbel", nil
+	default:
+		return nil, "", fmt.Errorf("Unknown bookmark format: %s (expected 'json' or 'xbel')", format)
+	}
+}
+
+// writeFileAtomic writes data to path without ever leaving a truncated
+// file behind if the process is interrupted mid-write: it writes to a
+// ".tmp" file in the same directory, fsyncs it, then renames it over
+// path. The rename is atomic on POSIX filesystems, so readers always see
+// either the old file or the fully-written new one.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("Failed to write temp file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("Failed to sync temp file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("Failed to close temp file: %w", err)
+	}
+
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("Failed to set file permissions: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("Failed to replace bookmarks file: %w", err)
+	}
+
+	return nil
+}
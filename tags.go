@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tag applies a list of "+tag" / "-tag" edits (a bare tag is treated as
+// "+tag") to the named bookmark and re-saves the store, matching the
+// ergonomics of Shiori's update command so edits can be scripted.
+func (s *BookmarkStore) Tag(name string, edits []string) error {
+	return s.mutate(func() error {
+		for i := range s.Bookmarks {
+			if s.Bookmarks[i].Name != name {
+				continue
+			}
+
+			tags := s.Bookmarks[i].Tags
+			for _, edit := range edits {
+				switch {
+				case strings.HasPrefix(edit, "-"):
+					tags = removeTag(tags, strings.TrimPrefix(edit, "-"))
+				case strings.HasPrefix(edit, "+"):
+					tags = addTag(tags, strings.TrimPrefix(edit, "+"))
+				default:
+					tags = addTag(tags, edit)
+				}
+			}
+
+			s.Bookmarks[i].Tags = tags
+			return nil
+		}
+
+		return fmt.Errorf("Bookmark not found: %s", name)
+	})
+}
+
+func addTag(tags []string, tag string) []string {
+	for _, t := range tags {
+		if t == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+func removeTag(tags []string, tag string) []string {
+	out := tags[:0]
+	for _, t := range tags {
+		if t != tag {
+			out = append(out, t)
+		}
+	}
+	return out
+}
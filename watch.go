@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// EventKind describes how a bookmark changed between two loads of the
+// store file.
+type EventKind int
+
+const (
+	EventAdded EventKind = iota
+	EventRemoved
+	EventModified
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventAdded:
+		return "added"
+	case EventRemoved:
+		return "removed"
+	case EventModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a single bookmark change detected by Watch.
+type Event struct {
+	Kind     EventKind
+	Bookmark Bookmark
+}
+
+// Watch observes the store's backing file with fsnotify and emits typed
+// Events by diffing the reloaded bookmark set against the previous
+// snapshot whenever the file changes externally, so a long-running
+// consumer (a TUI picker, a shell prompt integration, an editor plugin)
+// can react to edits made by another process without polling. The
+// returned channel is closed once ctx is done.
+//
+// Every save goes through writeFileAtomic's write-temp-then-rename
+// pattern, which replaces the watched file's inode rather than writing
+// into it. fsnotify can't follow a watch across that rename, so Watch
+// watches the parent directory instead and filters for events naming the
+// store file.
+func (s *BookmarkStore) Watch(ctx context.Context) (<-chan Event, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create file watcher: %w", err)
+	}
+
+	if err := watcher.Add(filepath.Dir(s.filePath)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("Failed to watch bookmarks directory: %w", err)
+	}
+
+	events := make(chan Event)
+	previous := append([]Bookmark(nil), s.Bookmarks...)
+
+	go func() {
+		defer watcher.Close()
+		defer close(events)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+			case we, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(we.Name) != s.filePath {
+					continue
+				}
+				if we.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+
+				current, err := s.storage.Load(s.filePath)
+				if err != nil {
+					continue
+				}
+
+				for _, e := range diffBookmarks(previous, current) {
+					select {
+					case events <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+				previous = current
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// diffBookmarks compares two loads of the store and reports what changed.
+func diffBookmarks(before, after []Bookmark) []Event {
+	beforeByName := make(map[string]Bookmark, len(before))
+	for _, b := range before {
+		beforeByName[b.Name] = b
+	}
+	afterByName := make(map[string]Bookmark, len(after))
+	for _, b := range after {
+		afterByName[b.Name] = b
+	}
+
+	var events []Event
+	for name, b := range afterByName {
+		prev, existed := beforeByName[name]
+		switch {
+		case !existed:
+			events = append(events, Event{Kind: EventAdded, Bookmark: b})
+		case prev.Path != b.Path || prev.Description != b.Description || !tagsEqual(prev.Tags, b.Tags):
+			events = append(events, Event{Kind: EventModified, Bookmark: b})
+		}
+	}
+	for name, b := range beforeByName {
+		if _, ok := afterByName[name]; !ok {
+			events = append(events, Event{Kind: EventRemoved, Bookmark: b})
+		}
+	}
+
+	return events
+}
+
+func tagsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
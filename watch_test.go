@@ -0,0 +1,54 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestDiffBookmarks(t *testing.T) {
+	before := []Bookmark{
+		{Name: "kept", Path: "/a"},
+		{Name: "changed", Path: "/b"},
+		{Name: "removed", Path: "/c"},
+	}
+	after := []Bookmark{
+		{Name: "kept", Path: "/a"},
+		{Name: "changed", Path: "/b2"},
+		{Name: "added", Path: "/d"},
+	}
+
+	events := diffBookmarks(before, after)
+	sort.Slice(events, func(i, j int) bool { return events[i].Bookmark.Name < events[j].Bookmark.Name })
+
+	if len(events) != 3 {
+		t.Fatalf("events = %+v, want 3", events)
+	}
+
+	want := map[string]EventKind{
+		"added":   EventAdded,
+		"changed": EventModified,
+		"removed": EventRemoved,
+	}
+	for _, e := range events {
+		if e.Kind != want[e.Bookmark.Name] {
+			t.Errorf("event for %q = %v, want %v", e.Bookmark.Name, e.Kind, want[e.Bookmark.Name])
+		}
+	}
+}
+
+func TestDiffBookmarksNoChange(t *testing.T) {
+	bookmarks := []Bookmark{{Name: "a", Path: "/a", Tags: []string{"x"}}}
+	if events := diffBookmarks(bookmarks, append([]Bookmark(nil), bookmarks...)); len(events) != 0 {
+		t.Errorf("events = %+v, want none for an unchanged set", events)
+	}
+}
+
+func TestDiffBookmarksTagChangeIsModified(t *testing.T) {
+	before := []Bookmark{{Name: "a", Path: "/a", Tags: []string{"x"}}}
+	after := []Bookmark{{Name: "a", Path: "/a", Tags: []string{"x", "y"}}}
+
+	events := diffBookmarks(before, after)
+	if len(events) != 1 || events[0].Kind != EventModified {
+		t.Errorf("events = %+v, want a single EventModified", events)
+	}
+}
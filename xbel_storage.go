@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// xbelStorage persists bookmarks as XBEL (XML Bookmark Exchange Language),
+// the interchange format used by KDE, GNOME, and tools like Amfora. A
+// bookmark whose name has a "/"-separated prefix is nested inside a
+// <folder> of that name; everything else lives at the document root. Tags
+// and descriptions round-trip through XBEL's <desc> and <info><metadata>
+// extension points, and CreatedAt round-trips through XBEL's native
+// "added" attribute.
+type xbelStorage struct{}
+
+// xbelMetadataOwner namespaces our tag metadata block so other XBEL
+// consumers (KDE, GNOME, Amfora) that don't understand it can ignore it.
+const xbelMetadataOwner = "bitlatte.bookmark"
+
+type xbelDocument struct {
+	XMLName   xml.Name       `xml:"xbel"`
+	Version   string         `xml:"version,attr"`
+	Bookmarks []xbelBookmark `xml:"bookmark"`
+	Folders   []xbelFolder   `xml:"folder"`
+}
+
+type xbelFolder struct {
+	Title     string         `xml:"title"`
+	Bookmarks []xbelBookmark `xml:"bookmark"`
+}
+
+type xbelBookmark struct {
+	Href  string    `xml:"href,attr"`
+	Added string    `xml:"added,attr,omitempty"`
+	Title string    `xml:"title"`
+	Desc  string    `xml:"desc,omitempty"`
+	Info  *xbelInfo `xml:"info,omitempty"`
+}
+
+type xbelInfo struct {
+	Metadata []xbelMetadata `xml:"metadata"`
+}
+
+type xbelMetadata struct {
+	Owner string `xml:"owner,attr"`
+	Tags  string `xml:"tags,attr"`
+}
+
+func (xbelStorage) Load(path string) ([]Bookmark, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read bookmarks file: %w", err)
+	}
+
+	var doc xbelDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Failed to parse bookmarks file: %w", err)
+	}
+
+	var bookmarks []Bookmark
+	for _, b := range doc.Bookmarks {
+		bookmarks = append(bookmarks, xbelToBookmark(b.Title, b))
+	}
+	for _, folder := range doc.Folders {
+		for _, b := range folder.Bookmarks {
+			bookmarks = append(bookmarks, xbelToBookmark(folder.Title+"/"+b.Title, b))
+		}
+	}
+
+	return bookmarks, nil
+}
+
+func (xbelStorage) Save(path string, bookmarks []Bookmark) error {
+	doc := xbelDocument{Version: "1.0"}
+
+	grouped := map[string][]xbelBookmark{}
+	var folders []string
+	for _, b := range bookmarks {
+		folder := ""
+		title := b.Name
+		if i := strings.LastIndex(b.Name, "/"); i >= 0 {
+			folder = b.Name[:i]
+			title = b.Name[i+1:]
+		}
+
+		entry := bookmarkToXBEL(b, title)
+		if folder == "" {
+			doc.Bookmarks = append(doc.Bookmarks, entry)
+			continue
+		}
+
+		if _, ok := grouped[folder]; !ok {
+			folders = append(folders, folder)
+		}
+		grouped[folder] = append(grouped[folder], entry)
+	}
+	sort.Strings(folders)
+
+	for _, folder := range folders {
+		doc.Folders = append(doc.Folders, xbelFolder{Title: folder, Bookmarks: grouped[folder]})
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to serialize bookmarks: %w", err)
+	}
+
+	out := append([]byte(xml.Header), data...)
+
+	return writeFileAtomic(path, out)
+}
+
+func bookmarkToXBEL(b Bookmark, title string) xbelBookmark {
+	entry := xbelBookmark{Href: "file://" + b.Path, Title: title, Desc: b.Description}
+	if !b.CreatedAt.IsZero() {
+		entry.Added = b.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if len(b.Tags) > 0 {
+		entry.Info = &xbelInfo{Metadata: []xbelMetadata{
+			{Owner: xbelMetadataOwner, Tags: strings.Join(b.Tags, ",")},
+		}}
+	}
+	return entry
+}
+
+func xbelToBookmark(name string, e xbelBookmark) Bookmark {
+	b := Bookmark{Name: name, Path: strings.TrimPrefix(e.Href, "file://"), Description: e.Desc}
+	if e.Added != "" {
+		if added, err := time.Parse(time.RFC3339, e.Added); err == nil {
+			b.CreatedAt = added
+		}
+	}
+	if e.Info != nil {
+		for _, m := range e.Info.Metadata {
+			if m.Owner == xbelMetadataOwner && m.Tags != "" {
+				b.Tags = strings.Split(m.Tags, ",")
+			}
+		}
+	}
+	return b
+}